@@ -0,0 +1,124 @@
+// Package cache reads and writes a memoized sequence cache file so repeated
+// invocations of a sequence CLI can resume instead of recomputing from
+// scratch. The on-disk format is a self-describing header comment followed
+// by CSV rows of index,value, so other sequences can share it safely.
+package cache
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// File is the in-memory representation of a cache file. Values[i] holds the
+// term at index i+1.
+type File struct {
+	Name    string
+	Modulus *big.Int // nil means no modulus was applied
+	Values  []*big.Int
+}
+
+// Load reads the cache file at path. A missing or empty file is not an
+// error; it yields an empty File so callers can start fresh, which also
+// covers a write interrupted right after os.Create truncated the file.
+func Load(path string) (*File, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &File{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	headerLine, err := reader.ReadString('\n')
+	if err != nil && headerLine == "" {
+		if errors.Is(err, io.EOF) {
+			return &File{}, nil
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	name, modulus, err := parseHeader(headerLine)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	rows, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	values := make([]*big.Int, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 || row[0] == "index" {
+			continue // skip the CSV header row, if present
+		}
+		v, ok := new(big.Int).SetString(row[1], 10)
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid cached value %q", path, row[1])
+		}
+		values = append(values, v)
+	}
+	return &File{Name: name, Modulus: modulus, Values: values}, nil
+}
+
+func parseHeader(line string) (name string, modulus *big.Int, err error) {
+	name = "Fibonacci"
+	line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+	for _, field := range strings.Fields(line) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "sequence":
+			name = value
+		case "mod":
+			if value == "none" {
+				continue
+			}
+			m, ok := new(big.Int).SetString(value, 10)
+			if !ok {
+				return "", nil, fmt.Errorf("invalid modulus %q in cache header", value)
+			}
+			modulus = m
+		}
+	}
+	return name, modulus, nil
+}
+
+// Save writes f back to path in the header+CSV format Load expects,
+// overwriting any existing file.
+func Save(path string, f *File) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	modulus := "none"
+	if f.Modulus != nil {
+		modulus = f.Modulus.String()
+	}
+	if _, err := fmt.Fprintf(out, "# sequence=%s mod=%s\n", f.Name, modulus); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(out)
+	if err := writer.Write([]string{"index", "value"}); err != nil {
+		return err
+	}
+	for i, v := range f.Values {
+		if err := writer.Write([]string{strconv.Itoa(i + 1), v.String()}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.csv")
+	want := &File{
+		Name:   "Lucas",
+		Values: []*big.Int{big.NewInt(2), big.NewInt(1), big.NewInt(3), big.NewInt(4)},
+	}
+
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.Name != want.Name {
+		t.Errorf("Name = %q, want %q", got.Name, want.Name)
+	}
+	if len(got.Values) != len(want.Values) {
+		t.Fatalf("len(Values) = %d, want %d", len(got.Values), len(want.Values))
+	}
+	for i := range want.Values {
+		if got.Values[i].Cmp(want.Values[i]) != 0 {
+			t.Errorf("Values[%d] = %s, want %s", i, got.Values[i], want.Values[i])
+		}
+	}
+}
+
+func TestLoadMissingFileReturnsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.csv")
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if f.Name != "" || len(f.Values) != 0 {
+		t.Errorf("Load(missing) = %+v, want zero value", f)
+	}
+}
+
+// TestLoadEmptyFileReturnsEmpty covers a write interrupted right after
+// os.Create truncated the file but before Save wrote the header.
+func TestLoadEmptyFileReturnsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.csv")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if f.Name != "" || len(f.Values) != 0 {
+		t.Errorf("Load(empty) = %+v, want zero value", f)
+	}
+}
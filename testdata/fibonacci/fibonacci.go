@@ -1,29 +1,121 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"math/big"
 	"os"
 	"strconv"
+	"strings"
+
+	"fibonacci/cache"
+	"fibonacci/output"
+	"fibonacci/sequences"
 )
 
 func main() {
 	var max = 15
-	if len(os.Args) > 1 {
-		max, _ = strconv.Atoi(os.Args[1])
-	}
-
-	var first, second = 1, 1
-	for i := 1; i <= max; i++ {
-		if i <= 2 {
-			if i == 2 {
-				fmt.Print(", ")
-			}
-			fmt.Printf("1")
-		} else {
-			var current = first + second
-			first, second = second, current
-			fmt.Printf(", %d", current)
+	var index = -1
+	var format string
+	var cachePath string
+	var seqName string
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	fs.StringVar(&format, "o", output.Plain, "output format: plain, table, csv, json")
+	fs.StringVar(&format, "output", output.Plain, "output format: plain, table, csv, json")
+	fs.IntVar(&index, "index", -1, "print only the term at this index (1-based) instead of the full sequence")
+	fs.StringVar(&cachePath, "cache", "", "path to a memoized sequence cache to read, extend, and write back")
+	fs.StringVar(&seqName, "seq", "fibonacci", "recurrence to compute: "+strings.Join(sequences.Names(), ", "))
+	fs.Parse(os.Args[1:])
+
+	if args := fs.Args(); len(args) > 0 {
+		max, _ = strconv.Atoi(args[0])
+	}
+
+	r, ok := sequences.Get(seqName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s: unknown sequence (available: %s)\n", seqName, strings.Join(sequences.Names(), ", "))
+		os.Exit(1)
+	}
+
+	if index == 0 {
+		fmt.Fprintln(os.Stderr, "-index is 1-based; the first term of a sequence is at index 1")
+		os.Exit(1)
+	}
+
+	var rows [][]string
+	if index >= 1 {
+		term, err := sequences.TermAt(r, index)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
+		rows = [][]string{{strconv.Itoa(index), term.String()}}
+	} else {
+		values, err := terms(r, max, cachePath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		rows = make([][]string, len(values))
+		for i, v := range values {
+			rows[i] = []string{strconv.Itoa(i + 1), v.String()}
+		}
+	}
+
+	if err := output.Print(format, []string{"index", "value"}, rows); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// terms returns the first max terms of r. If cachePath is non-empty, it is
+// read first; terms already present there are reused as-is and only the
+// missing suffix is generated, after which the extended cache is written
+// back to cachePath.
+func terms(r sequences.Recurrence, max int, cachePath string) ([]*big.Int, error) {
+	if cachePath == "" {
+		return generate(r, max), nil
+	}
+
+	cached, err := cache.Load(cachePath)
+	if err != nil {
+		return nil, err
+	}
+	if cached.Name != "" && !strings.EqualFold(cached.Name, r.Name) {
+		return nil, fmt.Errorf("%s: cache was written for sequence %q, not %q", cachePath, cached.Name, r.Name)
+	}
+	if len(cached.Values) >= max {
+		return cached.Values[:max], nil
+	}
+
+	cached.Name = strings.ToUpper(r.Name[:1]) + r.Name[1:]
+	cached.Values = extend(r, cached.Values, max)
+	if err := cache.Save(cachePath, cached); err != nil {
+		return nil, err
+	}
+	return cached.Values, nil
+}
+
+// generate returns the first max terms of r from scratch.
+func generate(r sequences.Recurrence, max int) []*big.Int {
+	return extend(r, nil, max)
+}
+
+// extend appends the terms from len(existing)+1 through max to a copy of
+// existing, using the tail of existing (or r's initial terms, if existing
+// holds fewer terms than the order of r) to seed the generator. existing is
+// assumed to hold the first len(existing) terms of r already.
+func extend(r sequences.Recurrence, existing []*big.Int, max int) []*big.Int {
+	if len(existing) >= max {
+		return existing[:max]
+	}
+
+	values := make([]*big.Int, len(existing), max)
+	copy(values, existing)
+
+	gen := sequences.ResumeGenerator(r, existing)
+	for i := len(values) + 1; i <= max; i++ {
+		values = append(values, gen.Next())
 	}
-	fmt.Println()
+	return values
 }
@@ -0,0 +1,131 @@
+// Package output provides pluggable writers for rendering tabular results
+// (header + rows of strings) to os.Stdout in a handful of common formats.
+// It exists so command-line tools built on top of this package can share a
+// single rendering path regardless of which sequence they compute.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Writer renders a header and a set of rows to w.
+type Writer interface {
+	Write(w io.Writer, header []string, rows [][]string) error
+}
+
+const (
+	Plain = "plain"
+	Table = "table"
+	CSV   = "csv"
+	JSON  = "json"
+)
+
+// For returns the Writer registered for the given format name.
+func For(name string) (Writer, error) {
+	switch name {
+	case Plain:
+		return plainWriter{}, nil
+	case Table:
+		return tableWriter{}, nil
+	case CSV:
+		return csvWriter{}, nil
+	case JSON:
+		return jsonWriter{}, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown output format", name)
+	}
+}
+
+// Print is a convenience wrapper that resolves the named format and writes
+// header/rows to os.Stdout.
+func Print(name string, header []string, rows [][]string) error {
+	writer, err := For(name)
+	if err != nil {
+		return err
+	}
+	return writer.Write(os.Stdout, header, rows)
+}
+
+// plainWriter prints the last column of each row as a single
+// comma-separated line, matching the original output of this tool.
+type plainWriter struct{}
+
+func (plainWriter) Write(w io.Writer, header []string, rows [][]string) error {
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		values[i] = row[len(row)-1]
+	}
+	_, err := fmt.Fprintln(w, strings.Join(values, ", "))
+	return err
+}
+
+// tableWriter prints header and rows as a whitespace-aligned table.
+type tableWriter struct{}
+
+func (tableWriter) Write(w io.Writer, header []string, rows [][]string) error {
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+	printRow := func(row []string) error {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = fmt.Sprintf("%-*s", widths[i], cell)
+		}
+		_, err := fmt.Fprintln(w, strings.TrimRight(strings.Join(cells, "  "), " "))
+		return err
+	}
+	if err := printRow(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := printRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvWriter prints header and rows as RFC 4180 CSV.
+type csvWriter struct{}
+
+func (csvWriter) Write(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// jsonWriter prints rows as a JSON array of objects keyed by header.
+type jsonWriter struct{}
+
+func (jsonWriter) Write(w io.Writer, header []string, rows [][]string) error {
+	records := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		record := make(map[string]string, len(header))
+		for j, h := range header {
+			record[h] = row[j]
+		}
+		records[i] = record
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+var (
+	testHeader = []string{"index", "value"}
+	testRows   = [][]string{{"1", "1"}, {"2", "1"}, {"3", "2"}}
+)
+
+func TestWriters(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{Plain, "1, 1, 2\n"},
+		{Table, "index  value\n1      1\n2      1\n3      2\n"},
+		{CSV, "index,value\n1,1\n2,1\n3,2\n"},
+		{JSON, `[
+  {
+    "index": "1",
+    "value": "1"
+  },
+  {
+    "index": "2",
+    "value": "1"
+  },
+  {
+    "index": "3",
+    "value": "2"
+  }
+]
+`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			writer, err := For(test.format)
+			if err != nil {
+				t.Fatalf("For(%q): %v", test.format, err)
+			}
+			var buf bytes.Buffer
+			if err := writer.Write(&buf, testHeader, testRows); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if got := buf.String(); got != test.want {
+				t.Errorf("%s output =\n%q\nwant\n%q", test.format, got, test.want)
+			}
+		})
+	}
+}
+
+func TestForUnknownFormat(t *testing.T) {
+	if _, err := For("xml"); err == nil {
+		t.Error("For(\"xml\"): expected error, got nil")
+	}
+}
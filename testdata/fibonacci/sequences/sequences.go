@@ -0,0 +1,207 @@
+// Package sequences implements a small pluggable subsystem for linear
+// recurrence sequences (Fibonacci, Lucas, Tribonacci, Pell, ...). Each
+// sequence is described by a Recurrence, terms are produced lazily by a
+// Generator, and an arbitrary term can be jumped to directly via matrix
+// exponentiation over the recurrence's companion matrix.
+package sequences
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Recurrence describes a linear recurrence of order len(Coeffs): the first
+// len(Init) terms are given directly by Init, and every later term is
+//
+//	term(n) = Coeffs[0]*term(n-1) + Coeffs[1]*term(n-2) + ... + Coeffs[m-1]*term(n-m)
+//
+// where m = len(Coeffs). Init and Coeffs must have equal, non-zero length.
+type Recurrence struct {
+	Name   string
+	Init   []*big.Int
+	Coeffs []*big.Int
+}
+
+var registry = map[string]Recurrence{}
+
+// Register adds r to the set of sequences selectable by name. Lookups are
+// case-insensitive.
+func Register(r Recurrence) {
+	registry[strings.ToLower(r.Name)] = r
+}
+
+// Get returns the registered recurrence with the given name.
+func Get(name string) (Recurrence, bool) {
+	r, ok := registry[strings.ToLower(name)]
+	return r, ok
+}
+
+// Names returns the names of all registered recurrences, sorted.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for _, r := range registry {
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	Register(Recurrence{Name: "fibonacci", Init: ints(1, 1), Coeffs: ints(1, 1)})
+	Register(Recurrence{Name: "lucas", Init: ints(2, 1), Coeffs: ints(1, 1)})
+	Register(Recurrence{Name: "tribonacci", Init: ints(0, 0, 1), Coeffs: ints(1, 1, 1)})
+	Register(Recurrence{Name: "pell", Init: ints(0, 1), Coeffs: ints(2, 1)})
+}
+
+func ints(values ...int64) []*big.Int {
+	result := make([]*big.Int, len(values))
+	for i, v := range values {
+		result[i] = big.NewInt(v)
+	}
+	return result
+}
+
+// Generator yields the terms of a Recurrence lazily, one at a time, via
+// Next. It implements the "first N terms" mode.
+type Generator struct {
+	coeffs []*big.Int
+	window []*big.Int // the last len(coeffs) terms produced, oldest first
+	n      int        // index of the last term returned
+}
+
+// NewGenerator returns a Generator that starts at term 1 of r.
+func NewGenerator(r Recurrence) *Generator {
+	window := make([]*big.Int, len(r.Init))
+	for i, v := range r.Init {
+		window[i] = new(big.Int).Set(v)
+	}
+	return &Generator{coeffs: r.Coeffs, window: window}
+}
+
+// ResumeGenerator returns a Generator whose next call to Next produces term
+// len(tail)+1 of r, given that tail already holds the first len(tail) terms
+// of r. This lets a caller continue a previously cached sequence without
+// recomputing the terms it already has.
+func ResumeGenerator(r Recurrence, tail []*big.Int) *Generator {
+	m := len(r.Coeffs)
+	window := make([]*big.Int, m)
+	if n := len(tail); n >= m {
+		for i := 0; i < m; i++ {
+			window[i] = new(big.Int).Set(tail[n-m+i])
+		}
+	} else {
+		for i := range window {
+			window[i] = new(big.Int).Set(r.Init[i])
+		}
+	}
+	return &Generator{coeffs: r.Coeffs, window: window, n: len(tail)}
+}
+
+// Next returns the next term of the sequence, starting with term 1.
+func (g *Generator) Next() *big.Int {
+	g.n++
+	if g.n <= len(g.window) {
+		return g.window[g.n-1]
+	}
+
+	m := len(g.coeffs)
+	next := new(big.Int)
+	for i, c := range g.coeffs {
+		term := new(big.Int).Mul(c, g.window[m-1-i])
+		next.Add(next, term)
+	}
+	g.window = append(g.window[1:], next)
+	return next
+}
+
+// TermAt returns the term at index k (1-based) of r, computed via matrix
+// exponentiation over the recurrence's companion matrix rather than by
+// stepping through every intermediate term. This makes it efficient for
+// large k regardless of which recurrence is selected.
+func TermAt(r Recurrence, k int) (*big.Int, error) {
+	m := len(r.Coeffs)
+	if m == 0 || len(r.Init) != m {
+		return nil, fmt.Errorf("%s: Init and Coeffs must have equal, non-zero length", r.Name)
+	}
+	if k < 1 {
+		return nil, fmt.Errorf("%s: index must be >= 1, got %d", r.Name, k)
+	}
+	if k <= m {
+		return new(big.Int).Set(r.Init[k-1]), nil
+	}
+
+	state := newMatrix(m, 1)
+	for i := 0; i < m; i++ {
+		state[i][0] = new(big.Int).Set(r.Init[m-1-i])
+	}
+	result := companion(r.Coeffs).pow(k - m).mul(state)
+	return result[0][0], nil
+}
+
+// matrix is a dense m x n matrix of big.Int, used only to implement the
+// companion-matrix exponentiation behind TermAt.
+type matrix [][]*big.Int
+
+func newMatrix(rows, cols int) matrix {
+	m := make(matrix, rows)
+	for i := range m {
+		m[i] = make([]*big.Int, cols)
+		for j := range m[i] {
+			m[i][j] = new(big.Int)
+		}
+	}
+	return m
+}
+
+func identity(n int) matrix {
+	m := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m[i][i].SetInt64(1)
+	}
+	return m
+}
+
+// companion builds the companion matrix of a recurrence with the given
+// coefficients, i.e. the matrix M such that M * (t(n), ..., t(n-m+1))^T =
+// (t(n+1), ..., t(n-m+2))^T.
+func companion(coeffs []*big.Int) matrix {
+	m := len(coeffs)
+	result := newMatrix(m, m)
+	for j, c := range coeffs {
+		result[0][j] = new(big.Int).Set(c)
+	}
+	for i := 1; i < m; i++ {
+		result[i][i-1].SetInt64(1)
+	}
+	return result
+}
+
+func (a matrix) mul(b matrix) matrix {
+	rows, inner, cols := len(a), len(b), len(b[0])
+	result := newMatrix(rows, cols)
+	term := new(big.Int)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			for k := 0; k < inner; k++ {
+				term.Mul(a[i][k], b[k][j])
+				result[i][j].Add(result[i][j], term)
+			}
+		}
+	}
+	return result
+}
+
+func (a matrix) pow(n int) matrix {
+	result := identity(len(a))
+	base := a
+	for n > 0 {
+		if n&1 == 1 {
+			result = result.mul(base)
+		}
+		base = base.mul(base)
+		n >>= 1
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package sequences
+
+import "testing"
+
+func TestTermAtMatchesGenerator(t *testing.T) {
+	for _, name := range Names() {
+		r, ok := Get(name)
+		if !ok {
+			t.Fatalf("Get(%q): not found", name)
+		}
+		gen := NewGenerator(r)
+		for i := 1; i <= 40; i++ {
+			want := gen.Next()
+			got, err := TermAt(r, i)
+			if err != nil {
+				t.Fatalf("%s: TermAt(%d): %v", name, i, err)
+			}
+			if got.Cmp(want) != 0 {
+				t.Errorf("%s: TermAt(%d) = %s, want %s", name, i, got, want)
+			}
+		}
+	}
+}
+
+func TestTermAtRejectsNonPositiveIndex(t *testing.T) {
+	r, _ := Get("fibonacci")
+	if _, err := TermAt(r, 0); err == nil {
+		t.Error("TermAt(0): expected error, got nil")
+	}
+}